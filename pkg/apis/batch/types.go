@@ -211,6 +211,175 @@ type JobSpec struct {
 	//
 	// +optional
 	Suspend *bool
+
+	// SuccessPolicy specifies the policy when the Job can be declared as
+	// succeeded based on the success of some indexes instead of all indexes.
+	// This field can only be set when .spec.completionMode=Indexed.
+	// +optional
+	SuccessPolicy *SuccessPolicy
+
+	// Specifies the limit for the number of retries within an index before
+	// marking this index as failed. When enabled, the number of failures
+	// per index is kept in the pod's batch.kubernetes.io/job-index-failure-count
+	// annotation. It can only be set when Job's completionMode=Indexed, and
+	// the Pod's restart policy is Never. The field is immutable.
+	// +optional
+	BackoffLimitPerIndex *int32
+
+	// Specifies the maximal number of failed indexes before marking the
+	// Job as failed, when backoffLimitPerIndex is set. Once the number of
+	// failed indexes exceeds this number the entire Job is marked as
+	// Failed and the execution is terminated. When left as null the job
+	// continues execution of all of its indexes and is marked with the
+	// Complete Job condition. It can only be specified when
+	// backoffLimitPerIndex is set. It can be null or up to
+	// completions. It is required and must be less than or equal to
+	// 10^4 when completions is greater than 10^4.
+	// +optional
+	MaxFailedIndexes *int32
+
+	// PodFailurePolicy specifies the policy of handling failed pods. In particular,
+	// it allows to specify the set of actions and conditions which need to be
+	// satisfied to take the associated action.
+	// If empty, the default behaviour applies - the counter of failed pods,
+	// represented by the jobs's .status.failed field, is incremented and it is
+	// checked against the backoffLimit. This field cannot be used in combination
+	// with .spec.restartPolicy=OnFailure.
+	// +optional
+	PodFailurePolicy *PodFailurePolicy
+}
+
+// PodFailurePolicy describes how failed pods influence the backoffLimit.
+type PodFailurePolicy struct {
+	// A list of pod failure policy rules. The rules are evaluated in order.
+	// Once a rule matches a Pod failure, the remaining of the rules are ignored.
+	// When no rule matches the Pod failure, the default handling applies - the
+	// counter of pod failures is incremented and it is checked against
+	// the backoffLimit.
+	// +listType=atomic
+	Rules []PodFailurePolicyRule
+}
+
+// PodFailurePolicyRule describes how a pod failure is handled when the
+// requirements are met for a given PodFailurePolicyRule.
+type PodFailurePolicyRule struct {
+	// Action specifies what action should be taken on a pod failure when the
+	// requirements are satisfied. One of:
+	// - FailJob: indicates that the pod's job is marked as Failed and all
+	//   running pods are terminated.
+	// - Ignore: indicates that the counter towards the .backoffLimit is not
+	//   incremented and a replacement pod is created.
+	// - Count: indicates that the pod is handled in the default way - the
+	//   counter towards the .backoffLimit is incremented.
+	Action PodFailurePolicyAction
+
+	// OnExitCodes represents the requirement on the container exit codes.
+	// +optional
+	OnExitCodes *PodFailurePolicyOnExitCodesRequirement
+
+	// OnPodConditions represents the requirement on the pod conditions. The
+	// requirement is represented as a list of pod condition patterns. The
+	// requirement is satisfied if at least one pattern matches an actual pod
+	// condition. At most one of OnExitCodes and OnPodConditions may be
+	// specified in a single rule.
+	// +listType=atomic
+	// +optional
+	OnPodConditions []PodFailurePolicyOnPodConditionsPattern
+}
+
+// PodFailurePolicyAction specifies how a Pod failure is handled.
+type PodFailurePolicyAction string
+
+const (
+	// PodFailurePolicyActionFailJob indicates that the Job is marked Failed
+	// and all running Pods are terminated.
+	PodFailurePolicyActionFailJob PodFailurePolicyAction = "FailJob"
+
+	// PodFailurePolicyActionIgnore indicates that the counter towards the
+	// .backoffLimit is not incremented and a replacement Pod is created.
+	PodFailurePolicyActionIgnore PodFailurePolicyAction = "Ignore"
+
+	// PodFailurePolicyActionCount indicates that the Pod failure is handled
+	// in the default way - the counter towards the .backoffLimit is
+	// incremented.
+	PodFailurePolicyActionCount PodFailurePolicyAction = "Count"
+)
+
+// PodFailurePolicyOnExitCodesOperator is the operator used to relate a
+// container's exit code to the specified values.
+type PodFailurePolicyOnExitCodesOperator string
+
+const (
+	// PodFailurePolicyOnExitCodesOpIn matches a requirement when the exit
+	// code is one of the specified Values.
+	PodFailurePolicyOnExitCodesOpIn PodFailurePolicyOnExitCodesOperator = "In"
+
+	// PodFailurePolicyOnExitCodesOpNotIn matches a requirement when the exit
+	// code is not one of the specified Values.
+	PodFailurePolicyOnExitCodesOpNotIn PodFailurePolicyOnExitCodesOperator = "NotIn"
+)
+
+// PodFailurePolicyOnExitCodesRequirement describes the requirement for
+// handling a failed pod based on its container exit codes.
+type PodFailurePolicyOnExitCodesRequirement struct {
+	// ContainerName restricts the check to the exit codes of the container
+	// with the given name. When null, the rule applies to all containers.
+	// +optional
+	ContainerName *string
+
+	// Operator specifies the relationship between the container exit code(s)
+	// and the specified Values. One of In, NotIn.
+	Operator PodFailurePolicyOnExitCodesOperator
+
+	// Values holds a set of container exit codes.
+	// +listType=set
+	Values []int32
+}
+
+// PodFailurePolicyOnPodConditionsPattern describes a pattern for matching an
+// actual pod condition type.
+type PodFailurePolicyOnPodConditionsPattern struct {
+	// Type specifies the required Pod condition type.
+	Type api.PodConditionType
+
+	// Status specifies the required Pod condition status. Defaults to True.
+	Status api.ConditionStatus
+}
+
+// SuccessPolicy describes when a Job can be declared as succeeded based on
+// the success of some indexes.
+type SuccessPolicy struct {
+	// rules represents the list of alternative rules for the declaring the
+	// Jobs as successful before `.status.succeeded >= .spec.completions`.
+	// Once any of the rules are met, the "SuccessCriteriaMet" condition is
+	// added, and the lingering Pods are removed. The terminal state for such
+	// a Job has the "Complete" condition. At most 20 elements are allowed.
+	// +listType=atomic
+	Rules []SuccessPolicyRule
+}
+
+// SuccessPolicyRule describes a rule for declaring a Job as succeeded.
+// Exactly one of SucceededIndexes and SucceededCount may be used in
+// combination to express a quorum of indexes.
+type SuccessPolicyRule struct {
+	// SucceededIndexes specifies the set of indexes which need to be
+	// contained in the actual set of the succeeded indexes for the Job to be
+	// declared as succeeded. The indexes are represented as intervals
+	// separated by commas, e.g. "0,3-5,7". The number are listed in
+	// increasing order. Three or more consecutive numbers are compressed and
+	// represented by the first and last element of the series. The indexes
+	// are within 0 to ".spec.completions-1" and must not contain duplicates.
+	// At least one element is required.
+	// +optional
+	SucceededIndexes *string
+
+	// SucceededCount specifies the minimal required size of the actual set
+	// of the succeeded indexes for the Job to be declared as succeeded. When
+	// SucceededIndexes is also specified, the check is constrained only to
+	// the indexes specified by SucceededIndexes. When SucceededIndexes is
+	// not specified, the check applies to all indexes of the Job.
+	// +optional
+	SucceededCount *int32
 }
 
 // JobStatus represents the current state of a Job.
@@ -268,6 +437,17 @@ type JobStatus struct {
 	// +optional
 	CompletedIndexes string
 
+	// FailedIndexes holds the failed indexes when spec.backoffLimitPerIndex
+	// is set. The indexes are represented in the text format analogous as for
+	// the `completedIndexes` field, ie. they are kept as decimal integers
+	// separated by commas. The numbers are listed in increasing order. Three
+	// or more consecutive numbers are compressed and represented by the first
+	// and last element of the series, separated by a hyphen.
+	// For example, if the failed indexes are 1, 3, 4, 5 and 7, they are
+	// represented as "1,3-5,7".
+	// +optional
+	FailedIndexes *string
+
 	// UncountedTerminatedPods holds the UIDs of Pods that have terminated but
 	// the job controller hasn't yet accounted for in the status counters.
 	//
@@ -313,8 +493,20 @@ const (
 	JobComplete JobConditionType = "Complete"
 	// JobFailed means the job has failed its execution.
 	JobFailed JobConditionType = "Failed"
+	// JobSuccessCriteriaMet means the Job has been declared as succeeded
+	// based on its SuccessPolicy, but the Job is not yet Complete: lingering
+	// Pods still need to be removed.
+	JobSuccessCriteriaMet JobConditionType = "SuccessCriteriaMet"
 )
 
+// JobReasonPodFailurePolicy is the Reason recorded on a Failed JobCondition
+// when a PodFailurePolicyRule with action FailJob matched a Pod failure.
+const JobReasonPodFailurePolicy = "PodFailurePolicy"
+
+// JobReasonSuccessPolicy is the Reason recorded on the SuccessCriteriaMet and
+// Complete JobConditions when a SuccessPolicyRule was satisfied.
+const JobReasonSuccessPolicy = "SuccessPolicy"
+
 // JobCondition describes current state of a job.
 type JobCondition struct {
 	// Type of job condition.
@@ -406,6 +598,14 @@ type CronJobSpec struct {
 	// This is a pointer to distinguish between explicit zero and not specified.
 	// +optional
 	FailedJobsHistoryLimit *int32
+
+	// The time zone name for the given schedule, see https://en.wikipedia.org/wiki/List_of_tz_database_time_zones.
+	// If not specified, this will rely on the time zone of the kube-controller-manager process.
+	// The set of valid names and the time zone offset is loaded from the system-wide time zone
+	// database by the API server during CronJob validation and the controller during the
+	// next scheduled run.
+	// +optional
+	TimeZone *string
 }
 
 // ConcurrencyPolicy describes how the job will be handled.
@@ -439,4 +639,10 @@ type CronJobStatus struct {
 	// Information when was the last time the job successfully completed.
 	// +optional
 	LastSuccessfulTime *metav1.Time
+
+	// TimeZone is the effective time zone the controller is using to
+	// calculate the next scheduled time, resolved from .spec.timeZone. It is
+	// reported for observability and does not affect scheduling.
+	// +optional
+	TimeZone *string
 }
@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	field "k8s.io/apimachinery/pkg/util/validation/field"
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+	api "k8s.io/kubernetes/pkg/apis/core"
+)
+
+func TestValidateBackoffLimitPerIndexRequiresRestartPolicyNever(t *testing.T) {
+	indexed := batch.IndexedCompletion
+	perIndex := int32(2)
+	spec := &batch.JobSpec{
+		CompletionMode:       &indexed,
+		BackoffLimitPerIndex: &perIndex,
+		Template: api.PodTemplateSpec{
+			Spec: api.PodSpec{
+				RestartPolicy: api.RestartPolicyOnFailure,
+			},
+		},
+	}
+	errs := ValidateBackoffLimitPerIndex(spec, field.NewPath("spec"))
+	if len(errs) == 0 {
+		t.Errorf("expected an error when backoffLimitPerIndex is set with restartPolicy=OnFailure")
+	}
+}
+
+func TestValidateBackoffLimitPerIndexRequiresIndexedCompletionMode(t *testing.T) {
+	perIndex := int32(2)
+	nonIndexed := batch.NonIndexedCompletion
+	spec := &batch.JobSpec{
+		CompletionMode:       &nonIndexed,
+		BackoffLimitPerIndex: &perIndex,
+	}
+	errs := ValidateBackoffLimitPerIndex(spec, field.NewPath("spec"))
+	if len(errs) == 0 {
+		t.Errorf("expected an error when backoffLimitPerIndex is set with a non-Indexed completionMode")
+	}
+}
+
+func TestValidateBackoffLimitPerIndexRejectsMaxFailedIndexesWithoutPerIndex(t *testing.T) {
+	maxFailed := int32(1)
+	spec := &batch.JobSpec{MaxFailedIndexes: &maxFailed}
+	errs := ValidateBackoffLimitPerIndex(spec, field.NewPath("spec"))
+	if len(errs) == 0 {
+		t.Errorf("expected an error when maxFailedIndexes is set without backoffLimitPerIndex")
+	}
+}
+
+func TestValidateBackoffLimitPerIndexRejectsMaxFailedIndexesAboveCompletions(t *testing.T) {
+	indexed := batch.IndexedCompletion
+	completions := int32(3)
+	perIndex := int32(1)
+	maxFailed := int32(5)
+	spec := &batch.JobSpec{
+		CompletionMode:       &indexed,
+		Completions:          &completions,
+		BackoffLimitPerIndex: &perIndex,
+		MaxFailedIndexes:     &maxFailed,
+	}
+	errs := ValidateBackoffLimitPerIndex(spec, field.NewPath("spec"))
+	if len(errs) == 0 {
+		t.Errorf("expected an error when maxFailedIndexes exceeds completions")
+	}
+}
+
+func TestValidateBackoffLimitPerIndexUpdateRejectsChange(t *testing.T) {
+	oldVal := int32(1)
+	newVal := int32(2)
+	oldSpec := &batch.JobSpec{BackoffLimitPerIndex: &oldVal}
+	newSpec := &batch.JobSpec{BackoffLimitPerIndex: &newVal}
+	errs := ValidateBackoffLimitPerIndexUpdate(newSpec, oldSpec, field.NewPath("spec", "backoffLimitPerIndex"))
+	if len(errs) == 0 {
+		t.Errorf("expected an error when backoffLimitPerIndex changes on update")
+	}
+}
+
+func TestValidateBackoffLimitPerIndexUpdateAcceptsNoChange(t *testing.T) {
+	val := int32(1)
+	oldSpec := &batch.JobSpec{BackoffLimitPerIndex: &val}
+	newSpec := &batch.JobSpec{BackoffLimitPerIndex: &val}
+	errs := ValidateBackoffLimitPerIndexUpdate(newSpec, oldSpec, field.NewPath("spec", "backoffLimitPerIndex"))
+	if len(errs) != 0 {
+		t.Errorf("expected no errors when backoffLimitPerIndex is unchanged, got: %v", errs)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	field "k8s.io/apimachinery/pkg/util/validation/field"
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+)
+
+func TestValidateCronJobSpecRejectsUnknownTimeZone(t *testing.T) {
+	zone := "Not/AZone"
+	spec := &batch.CronJobSpec{TimeZone: &zone}
+	errs := ValidateCronJobSpec(spec, field.NewPath("spec"))
+	if len(errs) == 0 {
+		t.Errorf("expected an error for an unknown time zone %q", zone)
+	}
+}
+
+func TestValidateCronJobSpecAcceptsKnownTimeZone(t *testing.T) {
+	zone := "America/New_York"
+	spec := &batch.CronJobSpec{TimeZone: &zone}
+	errs := ValidateCronJobSpec(spec, field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("expected no errors for a known time zone %q, got: %v", zone, errs)
+	}
+}
+
+func TestValidateCronJobSpecAcceptsUnsetTimeZone(t *testing.T) {
+	spec := &batch.CronJobSpec{}
+	errs := ValidateCronJobSpec(spec, field.NewPath("spec"))
+	if len(errs) != 0 {
+		t.Errorf("expected no errors when timeZone is unset, got: %v", errs)
+	}
+}
+
+func TestValidateCronJobSpecRejectsEmptyTimeZone(t *testing.T) {
+	zone := ""
+	spec := &batch.CronJobSpec{TimeZone: &zone}
+	errs := ValidateCronJobSpec(spec, field.NewPath("spec"))
+	if len(errs) == 0 {
+		t.Errorf("expected an error for an explicitly empty time zone")
+	}
+}
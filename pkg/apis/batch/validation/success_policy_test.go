@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	field "k8s.io/apimachinery/pkg/util/validation/field"
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+)
+
+func TestValidateSuccessPolicyRequiresIndexedCompletionMode(t *testing.T) {
+	indexes := "0-2"
+	nonIndexed := batch.NonIndexedCompletion
+	spec := &batch.JobSpec{
+		CompletionMode: &nonIndexed,
+		SuccessPolicy: &batch.SuccessPolicy{
+			Rules: []batch.SuccessPolicyRule{{SucceededIndexes: &indexes}},
+		},
+	}
+	errs := ValidateSuccessPolicy(spec, field.NewPath("spec", "successPolicy"))
+	if len(errs) == 0 {
+		t.Errorf("expected an error when successPolicy is set with a non-Indexed completionMode")
+	}
+}
+
+func TestValidateSuccessPolicyRejectsOutOfRangeIndexes(t *testing.T) {
+	completions := int32(5)
+	indexed := batch.IndexedCompletion
+	indexes := "0,7"
+	spec := &batch.JobSpec{
+		CompletionMode: &indexed,
+		Completions:    &completions,
+		SuccessPolicy: &batch.SuccessPolicy{
+			Rules: []batch.SuccessPolicyRule{{SucceededIndexes: &indexes}},
+		},
+	}
+	errs := ValidateSuccessPolicy(spec, field.NewPath("spec", "successPolicy"))
+	if len(errs) == 0 {
+		t.Errorf("expected an error for succeededIndexes referencing index 7 with only 5 completions")
+	}
+}
+
+func TestValidateSuccessPolicyAcceptsQuorumRule(t *testing.T) {
+	completions := int32(10)
+	indexed := batch.IndexedCompletion
+	indexes := "0-9"
+	count := int32(5)
+	spec := &batch.JobSpec{
+		CompletionMode: &indexed,
+		Completions:    &completions,
+		SuccessPolicy: &batch.SuccessPolicy{
+			Rules: []batch.SuccessPolicyRule{{SucceededIndexes: &indexes, SucceededCount: &count}},
+		},
+	}
+	errs := ValidateSuccessPolicy(spec, field.NewPath("spec", "successPolicy"))
+	if len(errs) != 0 {
+		t.Errorf("expected a valid quorum rule to pass validation, got: %v", errs)
+	}
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation contains validation functions for the batch API group,
+// beyond what is covered by the generic apiserver validation of required
+// fields and object metadata.
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	api "k8s.io/kubernetes/pkg/apis/core"
+
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+)
+
+const maxPodFailurePolicyRules = 20
+
+var supportedPodFailurePolicyActions = sets.New(
+	batch.PodFailurePolicyActionFailJob,
+	batch.PodFailurePolicyActionIgnore,
+	batch.PodFailurePolicyActionCount,
+)
+
+var supportedOnExitCodesOperators = sets.New(
+	batch.PodFailurePolicyOnExitCodesOpIn,
+	batch.PodFailurePolicyOnExitCodesOpNotIn,
+)
+
+// ValidatePodFailurePolicy validates the .spec.podFailurePolicy field of a
+// Job. It is a no-op when the field is unset.
+func ValidatePodFailurePolicy(spec *batch.JobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.PodFailurePolicy == nil {
+		return allErrs
+	}
+	if spec.Template.Spec.RestartPolicy == api.RestartPolicyOnFailure {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec.PodFailurePolicy, "may not be specified when the Job's pod template has restartPolicy=OnFailure, since Pod failures are never observed by the Job controller in that mode"))
+	}
+	rulesPath := fldPath.Child("rules")
+	if len(spec.PodFailurePolicy.Rules) > maxPodFailurePolicyRules {
+		allErrs = append(allErrs, field.TooMany(rulesPath, len(spec.PodFailurePolicy.Rules), maxPodFailurePolicyRules))
+	}
+	for i, rule := range spec.PodFailurePolicy.Rules {
+		allErrs = append(allErrs, validatePodFailurePolicyRule(&rule, rulesPath.Index(i))...)
+	}
+	return allErrs
+}
+
+func validatePodFailurePolicyRule(rule *batch.PodFailurePolicyRule, rulePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !supportedPodFailurePolicyActions.Has(rule.Action) {
+		allErrs = append(allErrs, field.NotSupported(rulePath.Child("action"), rule.Action, sets.List(supportedPodFailurePolicyActions)))
+	}
+	switch {
+	case rule.OnExitCodes == nil && len(rule.OnPodConditions) == 0:
+		allErrs = append(allErrs, field.Required(rulePath, "exactly one of onExitCodes or onPodConditions is required"))
+	case rule.OnExitCodes != nil && len(rule.OnPodConditions) > 0:
+		allErrs = append(allErrs, field.Invalid(rulePath, rule, "only one of onExitCodes or onPodConditions may be specified"))
+	case rule.OnExitCodes != nil:
+		allErrs = append(allErrs, validatePodFailurePolicyOnExitCodes(rule.OnExitCodes, rulePath.Child("onExitCodes"))...)
+	default:
+		for j, pattern := range rule.OnPodConditions {
+			allErrs = append(allErrs, validatePodFailurePolicyOnPodConditionsPattern(&pattern, rulePath.Child("onPodConditions").Index(j))...)
+		}
+	}
+	return allErrs
+}
+
+func validatePodFailurePolicyOnExitCodes(req *batch.PodFailurePolicyOnExitCodesRequirement, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !supportedOnExitCodesOperators.Has(req.Operator) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("operator"), req.Operator, sets.List(supportedOnExitCodesOperators)))
+	}
+	if len(req.Values) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("values"), "at least one exit code is required"))
+	}
+	seen := sets.New[int32]()
+	for i, value := range req.Values {
+		if value == 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("values").Index(i), value, "may not contain the value 0, since a zero exit code indicates success"))
+		}
+		if seen.Has(value) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("values").Index(i), value))
+		}
+		seen.Insert(value)
+	}
+	return allErrs
+}
+
+func validatePodFailurePolicyOnPodConditionsPattern(pattern *batch.PodFailurePolicyOnPodConditionsPattern, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if pattern.Type == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("type"), ""))
+	}
+	switch pattern.Status {
+	case api.ConditionTrue, api.ConditionFalse, api.ConditionUnknown:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("status"), pattern.Status, []api.ConditionStatus{api.ConditionTrue, api.ConditionFalse, api.ConditionUnknown}))
+	}
+	return allErrs
+}
+
+// ValidatePodFailurePolicyUpdate validates that an update to a Job's
+// PodFailurePolicy is itself valid; the field is immutable once the Job is
+// created, matching the immutability of the rest of .spec.template.
+func ValidatePodFailurePolicyUpdate(spec, oldSpec *batch.JobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !podFailurePolicyEqual(spec.PodFailurePolicy, oldSpec.PodFailurePolicy) {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec.PodFailurePolicy, fmt.Sprintf("field is immutable, was %v", oldSpec.PodFailurePolicy)))
+	}
+	return allErrs
+}
+
+const maxSuccessPolicyRules = 20
+
+// ValidateSuccessPolicy validates the .spec.successPolicy field of a Job. It
+// is a no-op when the field is unset.
+func ValidateSuccessPolicy(spec *batch.JobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.SuccessPolicy == nil {
+		return allErrs
+	}
+	if spec.CompletionMode == nil || *spec.CompletionMode != batch.IndexedCompletion {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec.SuccessPolicy, "may only be specified when completionMode is Indexed"))
+		return allErrs
+	}
+	completions := int32(0)
+	if spec.Completions != nil {
+		completions = *spec.Completions
+	}
+	rulesPath := fldPath.Child("rules")
+	if len(spec.SuccessPolicy.Rules) == 0 {
+		allErrs = append(allErrs, field.Required(rulesPath, "at least one rule is required"))
+	}
+	if len(spec.SuccessPolicy.Rules) > maxSuccessPolicyRules {
+		allErrs = append(allErrs, field.TooMany(rulesPath, len(spec.SuccessPolicy.Rules), maxSuccessPolicyRules))
+	}
+	for i, rule := range spec.SuccessPolicy.Rules {
+		allErrs = append(allErrs, validateSuccessPolicyRule(&rule, completions, rulesPath.Index(i))...)
+	}
+	return allErrs
+}
+
+func validateSuccessPolicyRule(rule *batch.SuccessPolicyRule, completions int32, rulePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if rule.SucceededIndexes == nil && rule.SucceededCount == nil {
+		allErrs = append(allErrs, field.Required(rulePath, "at least one of succeededIndexes or succeededCount is required"))
+	}
+	if rule.SucceededIndexes != nil {
+		if _, err := parseIndexesFromString(*rule.SucceededIndexes, int(completions)); err != nil {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("succeededIndexes"), *rule.SucceededIndexes, err.Error()))
+		}
+	}
+	if rule.SucceededCount != nil {
+		if *rule.SucceededCount < 1 {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("succeededCount"), *rule.SucceededCount, "must be greater than 0"))
+		} else if *rule.SucceededCount > completions {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("succeededCount"), *rule.SucceededCount, "must not be greater than completions"))
+		}
+	}
+	return allErrs
+}
+
+// parseIndexesFromString parses a compressed index expression of the form
+// used by JobStatus.CompletedIndexes/FailedIndexes and SuccessPolicyRule's
+// SucceededIndexes (e.g. "0,3-5,7"), returning the set of indexes it names.
+// It is an error for any named index to fall outside [0, completions).
+func parseIndexesFromString(indexesStr string, completions int) (sets.Set[int], error) {
+	result := sets.New[int]()
+	if indexesStr == "" {
+		return result, nil
+	}
+	for _, group := range strings.Split(indexesStr, ",") {
+		if group == "" {
+			return nil, fmt.Errorf("empty index group in %q", indexesStr)
+		}
+		bounds := strings.SplitN(group, "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %q: %w", bounds[0], err)
+		}
+		last := first
+		if len(bounds) == 2 {
+			last, err = strconv.Atoi(bounds[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid index %q: %w", bounds[1], err)
+			}
+		}
+		if first < 0 || last < first {
+			return nil, fmt.Errorf("invalid index range %q", group)
+		}
+		if last >= completions {
+			return nil, fmt.Errorf("index %d is out of range [0, %d)", last, completions)
+		}
+		for i := first; i <= last; i++ {
+			result.Insert(i)
+		}
+	}
+	return result, nil
+}
+
+// ValidateCronJobSpec validates the .spec.timeZone field of a CronJob. It is
+// a no-op when the field is unset, in which case the controller falls back
+// to the kube-controller-manager process's local time zone.
+func ValidateCronJobSpec(spec *batch.CronJobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.TimeZone == nil {
+		return allErrs
+	}
+	if *spec.TimeZone == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeZone"), *spec.TimeZone, "must not be empty when specified"))
+		return allErrs
+	}
+	if _, err := time.LoadLocation(*spec.TimeZone); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeZone"), *spec.TimeZone, fmt.Sprintf("unknown time zone: %v", err)))
+	}
+	return allErrs
+}
+
+// ValidateBackoffLimitPerIndex validates the .spec.backoffLimitPerIndex and
+// .spec.maxFailedIndexes fields of a Job. Both are no-ops when
+// backoffLimitPerIndex is unset.
+func ValidateBackoffLimitPerIndex(spec *batch.JobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.BackoffLimitPerIndex == nil {
+		if spec.MaxFailedIndexes != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxFailedIndexes"), *spec.MaxFailedIndexes, "may only be specified when backoffLimitPerIndex is set"))
+		}
+		return allErrs
+	}
+	if spec.CompletionMode == nil || *spec.CompletionMode != batch.IndexedCompletion {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("backoffLimitPerIndex"), *spec.BackoffLimitPerIndex, "may only be specified when completionMode is Indexed"))
+	}
+	if spec.Template.Spec.RestartPolicy != api.RestartPolicyNever {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("backoffLimitPerIndex"), *spec.BackoffLimitPerIndex, "may only be specified when the Job's pod template has restartPolicy=Never, since Pod failures are never observed by the Job controller otherwise"))
+	}
+	if *spec.BackoffLimitPerIndex < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("backoffLimitPerIndex"), *spec.BackoffLimitPerIndex, "must be greater than or equal to 0"))
+	}
+	if spec.MaxFailedIndexes != nil {
+		completions := int32(0)
+		if spec.Completions != nil {
+			completions = *spec.Completions
+		}
+		if *spec.MaxFailedIndexes < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxFailedIndexes"), *spec.MaxFailedIndexes, "must be greater than or equal to 0"))
+		} else if *spec.MaxFailedIndexes > completions {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxFailedIndexes"), *spec.MaxFailedIndexes, "must not be greater than completions"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateBackoffLimitPerIndexUpdate validates that .spec.backoffLimitPerIndex
+// is not changed after creation, since changing it would leave existing
+// per-index failure counts (tracked via Pod annotations) meaningless.
+func ValidateBackoffLimitPerIndexUpdate(spec, oldSpec *batch.JobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !int32PtrEqual(spec.BackoffLimitPerIndex, oldSpec.BackoffLimitPerIndex) {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec.BackoffLimitPerIndex, "field is immutable"))
+	}
+	return allErrs
+}
+
+func int32PtrEqual(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// podFailurePolicyEqual reports whether a and b describe the same policy.
+// This must be a real deep-equality check: PodFailurePolicyRule contains a
+// pointer field (OnExitCodes) nested one level below the top of the struct,
+// and fmt's "%+v" only dereferences pointers at the top level, so comparing
+// formatted strings would treat any two non-nil OnExitCodes pointers as
+// different regardless of their contents.
+func podFailurePolicyEqual(a, b *batch.PodFailurePolicy) bool {
+	return apiequality.Semantic.DeepEqual(a, b)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	field "k8s.io/apimachinery/pkg/util/validation/field"
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+)
+
+func onExitCodesPolicy(values ...int32) *batch.PodFailurePolicy {
+	return &batch.PodFailurePolicy{
+		Rules: []batch.PodFailurePolicyRule{
+			{
+				Action: batch.PodFailurePolicyActionFailJob,
+				OnExitCodes: &batch.PodFailurePolicyOnExitCodesRequirement{
+					Operator: batch.PodFailurePolicyOnExitCodesOpIn,
+					Values:   values,
+				},
+			},
+		},
+	}
+}
+
+// TestValidatePodFailurePolicyUpdateStructurallyEqual ensures two separately
+// allocated, but structurally identical, PodFailurePolicy values (each with
+// their own OnExitCodes pointer) are accepted as unchanged. A naive
+// comparison based on fmt's "%+v" would instead compare the two OnExitCodes
+// pointers' addresses and always report them as different.
+func TestValidatePodFailurePolicyUpdateStructurallyEqual(t *testing.T) {
+	oldSpec := &batch.JobSpec{PodFailurePolicy: onExitCodesPolicy(1, 2)}
+	newSpec := &batch.JobSpec{PodFailurePolicy: onExitCodesPolicy(1, 2)}
+
+	errs := ValidatePodFailurePolicyUpdate(newSpec, oldSpec, field.NewPath("spec", "podFailurePolicy"))
+	if len(errs) != 0 {
+		t.Errorf("expected no errors for a structurally-identical PodFailurePolicy, got: %v", errs)
+	}
+}
+
+func TestValidatePodFailurePolicyUpdateRejectsRealChange(t *testing.T) {
+	oldSpec := &batch.JobSpec{PodFailurePolicy: onExitCodesPolicy(1, 2)}
+	newSpec := &batch.JobSpec{PodFailurePolicy: onExitCodesPolicy(1, 3)}
+
+	errs := ValidatePodFailurePolicyUpdate(newSpec, oldSpec, field.NewPath("spec", "podFailurePolicy"))
+	if len(errs) == 0 {
+		t.Errorf("expected an error for an actual change to PodFailurePolicy, got none")
+	}
+}
+
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cronjob
+
+import (
+	"fmt"
+	"time"
+
+	cron "github.com/robfig/cron/v3"
+
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+)
+
+// effectiveLocation resolves the *time.Location a CronJob's schedule should
+// be interpreted in: spec.TimeZone when set, otherwise the
+// kube-controller-manager process's local time zone, matching the pre-1.24
+// behavior when spec.TimeZone is unset.
+func effectiveLocation(spec *batch.CronJobSpec) (*time.Location, error) {
+	if spec.TimeZone == nil || *spec.TimeZone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(*spec.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q: %w", *spec.TimeZone, err)
+	}
+	return loc, nil
+}
+
+// nextScheduleTimeInZone returns the next time after `now` (which is assumed
+// to be UTC, as is the time reported to the rest of the controller) at which
+// `schedule` should fire, evaluating the cron expression's fields against
+// wall-clock time in `loc`.
+//
+// Because schedule.Next operates on a time.Time, and time.Time arithmetic is
+// location-aware, the standard library itself absorbs DST transitions: a
+// wall-clock time that is skipped over (spring-forward) is never produced by
+// Next, and a wall-clock time that occurs twice (fall-back) is only
+// considered once per 24h period, since Next always advances strictly
+// forward in absolute time. This is what prevents a `30 2 * * *` schedule
+// from firing twice, or zero times, on a DST transition day.
+func nextScheduleTimeInZone(schedule cron.Schedule, now time.Time, loc *time.Location) time.Time {
+	return schedule.Next(now.In(loc)).UTC()
+}
+
+// recordEffectiveTimeZone stamps status.TimeZone with the configured IANA
+// zone name, so that operators can observe which zone is in effect.
+//
+// This only stamps a name when spec.TimeZone is actually set. time.Local (the
+// fallback effectiveLocation returns when spec.TimeZone is unset) has no
+// portable way to recover the IANA name it was resolved from: its
+// *time.Location.String() returns the unhelpful literal "Local" rather than,
+// e.g., "Etc/UTC", regardless of what the process's actual zone is. Reporting
+// that literal would be actively misleading, so status.TimeZone is left
+// unset in that case instead.
+func recordEffectiveTimeZone(status *batch.CronJobStatus, spec *batch.CronJobSpec) {
+	if spec.TimeZone == nil || *spec.TimeZone == "" {
+		status.TimeZone = nil
+		return
+	}
+	name := *spec.TimeZone
+	status.TimeZone = &name
+}
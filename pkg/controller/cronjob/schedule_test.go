@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cronjob
+
+import (
+	"testing"
+	"time"
+
+	cron "github.com/robfig/cron/v3"
+
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+)
+
+func TestRecordEffectiveTimeZoneUnsetWhenSpecTimeZoneUnset(t *testing.T) {
+	status := &batch.CronJobStatus{}
+	recordEffectiveTimeZone(status, &batch.CronJobSpec{})
+	if status.TimeZone != nil {
+		t.Errorf("expected status.TimeZone to stay unset rather than recording the literal \"Local\", got %q", *status.TimeZone)
+	}
+}
+
+func TestRecordEffectiveTimeZoneRecordsConfiguredZone(t *testing.T) {
+	zone := "America/New_York"
+	status := &batch.CronJobStatus{}
+	recordEffectiveTimeZone(status, &batch.CronJobSpec{TimeZone: &zone})
+	if status.TimeZone == nil || *status.TimeZone != zone {
+		t.Errorf("expected status.TimeZone to be %q, got %v", zone, status.TimeZone)
+	}
+}
+
+// TestNextScheduleTimeInZoneSpringForward checks that a schedule landing on
+// a skipped wall-clock time during a spring-forward DST transition does not
+// fire twice, nor get stuck, across the transition.
+func TestNextScheduleTimeInZoneSpringForward(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	schedule, err := cron.ParseStandard("30 2 * * *")
+	if err != nil {
+		t.Fatalf("ParseStandard: %v", err)
+	}
+	// 2023-03-12 is a spring-forward day in America/New_York: 02:00-02:59
+	// does not exist.
+	now := time.Date(2023, 3, 11, 12, 0, 0, 0, time.UTC)
+	next := nextScheduleTimeInZone(schedule, now, loc)
+	localNext := next.In(loc)
+	if localNext.Hour() == 2 && localNext.Day() == 12 {
+		t.Errorf("expected the 02:30 occurrence on the skipped day to be adjusted forward, got %v", localNext)
+	}
+	// Computing the next occurrence after that one should not return the
+	// same instant again (no infinite loop / duplicate firing).
+	after := nextScheduleTimeInZone(schedule, next, loc)
+	if !after.After(next) {
+		t.Errorf("expected strictly increasing schedule times, got %v then %v", next, after)
+	}
+}
+
+func TestNextScheduleTimeInZoneFallBack(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	schedule, err := cron.ParseStandard("30 1 * * *")
+	if err != nil {
+		t.Fatalf("ParseStandard: %v", err)
+	}
+	// 2023-11-05 is a fall-back day in America/New_York: 01:00-01:59 occurs
+	// twice. The schedule must still fire exactly once for that calendar
+	// day when walking forward occurrence by occurrence.
+	now := time.Date(2023, 11, 4, 12, 0, 0, 0, time.UTC)
+	first := nextScheduleTimeInZone(schedule, now, loc)
+	second := nextScheduleTimeInZone(schedule, first, loc)
+	if first.In(loc).Day() == second.In(loc).Day() {
+		t.Errorf("expected only one 01:30 occurrence on the fall-back day, got consecutive occurrences on the same day: %v, %v", first.In(loc), second.In(loc))
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+)
+
+// jobIndexFailureCountAnnotation records, on each Pod created for a
+// completion index, how many Pods have already failed for that index. It is
+// only set and consulted when .spec.backoffLimitPerIndex is in use.
+const jobIndexFailureCountAnnotation = "batch.kubernetes.io/job-index-failure-count"
+
+// podIndexFailureCount returns the number of prior failures recorded for the
+// completion index that pod belongs to, i.e. the value that was stamped on
+// pod when it was created as a replacement.
+func podIndexFailureCount(pod *v1.Pod) int32 {
+	v, ok := pod.Annotations[jobIndexFailureCountAnnotation]
+	if !ok {
+		return 0
+	}
+	count, err := strconv.Atoi(v)
+	if err != nil || count < 0 {
+		return 0
+	}
+	return int32(count)
+}
+
+// nextIndexFailureCount returns the failure count to stamp on a replacement
+// Pod, given the Pod that just failed for the same index.
+func nextIndexFailureCount(failedPod *v1.Pod) string {
+	return strconv.Itoa(int(podIndexFailureCount(failedPod)) + 1)
+}
+
+// indexBackoffLimitExceeded reports whether the index that failedPod belongs
+// to has exhausted its per-index retry budget, i.e. spec.backoffLimitPerIndex
+// has been reached after counting this failure. When that happens, the
+// index is marked permanently failed instead of being retried.
+func indexBackoffLimitExceeded(spec *batch.JobSpec, failedPod *v1.Pod) bool {
+	if spec.BackoffLimitPerIndex == nil {
+		return false
+	}
+	return podIndexFailureCount(failedPod) >= *spec.BackoffLimitPerIndex
+}
+
+// maxFailedIndexesExceeded reports whether the number of permanently failed
+// indexes exceeds spec.maxFailedIndexes. Once true, the Job controller marks
+// the whole Job Failed instead of continuing to schedule the remaining
+// indexes.
+func maxFailedIndexesExceeded(spec *batch.JobSpec, failedIndexes sets.Set[int]) bool {
+	if spec.MaxFailedIndexes == nil {
+		return false
+	}
+	return int32(failedIndexes.Len()) > *spec.MaxFailedIndexes
+}
+
+// addFailedIndex returns the FailedIndexes encoding that results from adding
+// index to the set already encoded by failedIndexesStr, using the same
+// compressed, comma/hyphen-separated format as CompletedIndexes.
+func addFailedIndex(failedIndexesStr *string, index int) string {
+	indexes := sets.New[int]()
+	if failedIndexesStr != nil {
+		indexes = parseIndexSet(*failedIndexesStr)
+	}
+	indexes.Insert(index)
+	return formatIndexSet(indexes)
+}
+
+// formatIndexSet renders a set of completion indexes using the compressed
+// encoding shared by CompletedIndexes and FailedIndexes: indexes are listed
+// in increasing order, and runs of three or more consecutive indexes are
+// compressed to "first-last".
+func formatIndexSet(indexes sets.Set[int]) string {
+	if indexes.Len() == 0 {
+		return ""
+	}
+	sorted := sets.List(indexes)
+	sort.Ints(sorted)
+	var b strings.Builder
+	for i := 0; i < len(sorted); {
+		start := i
+		for i+1 < len(sorted) && sorted[i+1] == sorted[i]+1 {
+			i++
+		}
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		if i-start >= 2 {
+			b.WriteString(strconv.Itoa(sorted[start]))
+			b.WriteByte('-')
+			b.WriteString(strconv.Itoa(sorted[i]))
+		} else {
+			for j := start; j <= i; j++ {
+				if j > start {
+					b.WriteByte(',')
+				}
+				b.WriteString(strconv.Itoa(sorted[j]))
+			}
+		}
+		i++
+	}
+	return b.String()
+}
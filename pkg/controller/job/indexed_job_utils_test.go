@@ -0,0 +1,118 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+)
+
+func podWithIndexFailureCount(count string) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{}}
+	if count != "" {
+		pod.Annotations = map[string]string{jobIndexFailureCountAnnotation: count}
+	}
+	return pod
+}
+
+func TestPodIndexFailureCountDefaultsToZero(t *testing.T) {
+	pod := podWithIndexFailureCount("")
+	if got := podIndexFailureCount(pod); got != 0 {
+		t.Errorf("expected 0 for a Pod with no failure count annotation, got %d", got)
+	}
+}
+
+func TestPodIndexFailureCountParsesAnnotation(t *testing.T) {
+	pod := podWithIndexFailureCount("3")
+	if got := podIndexFailureCount(pod); got != 3 {
+		t.Errorf("expected 3, got %d", got)
+	}
+}
+
+func TestNextIndexFailureCountIncrements(t *testing.T) {
+	pod := podWithIndexFailureCount("3")
+	if got := nextIndexFailureCount(pod); got != "4" {
+		t.Errorf("expected \"4\", got %q", got)
+	}
+}
+
+func TestIndexBackoffLimitExceeded(t *testing.T) {
+	limit := int32(2)
+	spec := &batch.JobSpec{BackoffLimitPerIndex: &limit}
+	if indexBackoffLimitExceeded(spec, podWithIndexFailureCount("1")) {
+		t.Errorf("expected limit not exceeded at failure count 1 with a limit of 2")
+	}
+	if !indexBackoffLimitExceeded(spec, podWithIndexFailureCount("2")) {
+		t.Errorf("expected limit exceeded at failure count 2 with a limit of 2")
+	}
+}
+
+func TestIndexBackoffLimitExceededUnsetNeverExceeds(t *testing.T) {
+	spec := &batch.JobSpec{}
+	if indexBackoffLimitExceeded(spec, podWithIndexFailureCount("1000")) {
+		t.Errorf("expected limit never exceeded when backoffLimitPerIndex is unset")
+	}
+}
+
+func TestMaxFailedIndexesExceeded(t *testing.T) {
+	maxFailed := int32(2)
+	spec := &batch.JobSpec{MaxFailedIndexes: &maxFailed}
+	if maxFailedIndexesExceeded(spec, sets.New[int](0, 1)) {
+		t.Errorf("expected not exceeded at 2 failed indexes with a max of 2")
+	}
+	if !maxFailedIndexesExceeded(spec, sets.New[int](0, 1, 2)) {
+		t.Errorf("expected exceeded at 3 failed indexes with a max of 2")
+	}
+}
+
+func TestAddFailedIndex(t *testing.T) {
+	got := addFailedIndex(nil, 3)
+	if got != "3" {
+		t.Errorf("expected \"3\" for an empty starting set, got %q", got)
+	}
+	existing := "0,1"
+	got = addFailedIndex(&existing, 2)
+	if got != "0-2" {
+		t.Errorf("expected \"0-2\" after adding an adjacent index, got %q", got)
+	}
+}
+
+func TestFormatIndexSet(t *testing.T) {
+	cases := map[string]struct {
+		indexes []int
+		want    string
+	}{
+		"empty":               {nil, ""},
+		"single":              {[]int{5}, "5"},
+		"non-contiguous pair": {[]int{1, 3}, "1,3"},
+		"contiguous run":      {[]int{0, 1, 2, 3}, "0-3"},
+		"mixed":               {[]int{0, 1, 2, 5, 7, 8, 9}, "0-2,5,7-9"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := formatIndexSet(sets.New[int](tc.indexes...))
+			if got != tc.want {
+				t.Errorf("formatIndexSet(%v) = %q, want %q", tc.indexes, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+)
+
+// matchPodFailurePolicy evaluates the rules of a PodFailurePolicy, in order,
+// against a single failed Pod. It returns the action of the first matching
+// rule and true, or batch.PodFailurePolicyActionCount and false if no rule
+// matches (the default handling: count the failure towards backoffLimit).
+//
+// The Job controller calls this for every observed Pod failure before
+// deciding how to update the Failed counter: Ignore leaves the counter (and
+// backoffLimit accounting) untouched and creates a replacement Pod, FailJob
+// marks the Job as Failed immediately, and Count (explicit or by default)
+// falls through to the pre-existing behavior.
+func matchPodFailurePolicy(policy *batch.PodFailurePolicy, pod *v1.Pod) (batch.PodFailurePolicyAction, bool) {
+	if policy == nil || pod.Status.Phase != v1.PodFailed {
+		return batch.PodFailurePolicyActionCount, false
+	}
+	for _, rule := range policy.Rules {
+		if podFailurePolicyRuleMatches(&rule, pod) {
+			return rule.Action, true
+		}
+	}
+	return batch.PodFailurePolicyActionCount, false
+}
+
+func podFailurePolicyRuleMatches(rule *batch.PodFailurePolicyRule, pod *v1.Pod) bool {
+	if rule.OnExitCodes != nil {
+		return onExitCodesRequirementMatches(rule.OnExitCodes, pod)
+	}
+	for _, pattern := range rule.OnPodConditions {
+		if onPodConditionsPatternMatches(pattern, pod) {
+			return true
+		}
+	}
+	return false
+}
+
+func onExitCodesRequirementMatches(req *batch.PodFailurePolicyOnExitCodesRequirement, pod *v1.Pod) bool {
+	for _, cs := range terminatedContainerStatuses(pod) {
+		if req.ContainerName != nil && *req.ContainerName != cs.Name {
+			continue
+		}
+		exitCode := cs.State.Terminated.ExitCode
+		if exitCode == 0 {
+			// A container that exited successfully did not contribute to
+			// the Pod's failure; only failing containers' exit codes are
+			// relevant to In/NotIn matching.
+			continue
+		}
+		isIn := containsExitCode(req.Values, exitCode)
+		switch req.Operator {
+		case batch.PodFailurePolicyOnExitCodesOpIn:
+			if isIn {
+				return true
+			}
+		case batch.PodFailurePolicyOnExitCodesOpNotIn:
+			if !isIn {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func terminatedContainerStatuses(pod *v1.Pod) []v1.ContainerStatus {
+	var statuses []v1.ContainerStatus
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.State.Terminated != nil {
+			statuses = append(statuses, cs)
+		}
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Terminated != nil {
+			statuses = append(statuses, cs)
+		}
+	}
+	return statuses
+}
+
+func containsExitCode(values []int32, exitCode int32) bool {
+	for _, v := range values {
+		if v == exitCode {
+			return true
+		}
+	}
+	return false
+}
+
+func onPodConditionsPatternMatches(pattern batch.PodFailurePolicyOnPodConditionsPattern, pod *v1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.PodConditionType(pattern.Type) && cond.Status == v1.ConditionStatus(pattern.Status) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+)
+
+func terminatedStatus(name string, exitCode int32) v1.ContainerStatus {
+	return v1.ContainerStatus{
+		Name:  name,
+		State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: exitCode}},
+	}
+}
+
+func TestMatchPodFailurePolicyOnExitCodesIgnoresSuccessfulContainers(t *testing.T) {
+	req := &batch.PodFailurePolicyOnExitCodesRequirement{
+		Operator: batch.PodFailurePolicyOnExitCodesOpNotIn,
+		Values:   []int32{42},
+	}
+	pod := &v1.Pod{
+		Status: v1.PodStatus{
+			Phase: v1.PodFailed,
+			ContainerStatuses: []v1.ContainerStatus{
+				terminatedStatus("sidecar", 0),  // succeeded, must not count
+				terminatedStatus("main", 42),    // failed, but exit code IS in Values
+			},
+		},
+	}
+	if onExitCodesRequirementMatches(req, pod) {
+		t.Errorf("expected NotIn{42} not to match when the only failing container's exit code is 42, even though an unrelated container exited 0")
+	}
+}
+
+func TestMatchPodFailurePolicyOnExitCodesMatchesFailingContainer(t *testing.T) {
+	req := &batch.PodFailurePolicyOnExitCodesRequirement{
+		Operator: batch.PodFailurePolicyOnExitCodesOpNotIn,
+		Values:   []int32{42},
+	}
+	pod := &v1.Pod{
+		Status: v1.PodStatus{
+			Phase: v1.PodFailed,
+			ContainerStatuses: []v1.ContainerStatus{
+				terminatedStatus("sidecar", 0),
+				terminatedStatus("main", 137), // failed with an unrelated code
+			},
+		},
+	}
+	if !onExitCodesRequirementMatches(req, pod) {
+		t.Errorf("expected NotIn{42} to match when the failing container's exit code (137) is not in Values")
+	}
+}
+
+func TestMatchPodFailurePolicyFirstRuleWins(t *testing.T) {
+	containerName := "main"
+	policy := &batch.PodFailurePolicy{
+		Rules: []batch.PodFailurePolicyRule{
+			{
+				Action: batch.PodFailurePolicyActionIgnore,
+				OnExitCodes: &batch.PodFailurePolicyOnExitCodesRequirement{
+					ContainerName: &containerName,
+					Operator:      batch.PodFailurePolicyOnExitCodesOpIn,
+					Values:        []int32{1},
+				},
+			},
+			{
+				Action: batch.PodFailurePolicyActionFailJob,
+				OnExitCodes: &batch.PodFailurePolicyOnExitCodesRequirement{
+					Operator: batch.PodFailurePolicyOnExitCodesOpIn,
+					Values:   []int32{1},
+				},
+			},
+		},
+	}
+	pod := &v1.Pod{
+		Status: v1.PodStatus{
+			Phase:             v1.PodFailed,
+			ContainerStatuses: []v1.ContainerStatus{terminatedStatus(containerName, 1)},
+		},
+	}
+	action, matched := matchPodFailurePolicy(policy, pod)
+	if !matched || action != batch.PodFailurePolicyActionIgnore {
+		t.Errorf("got (%v, %v), want (Ignore, true)", action, matched)
+	}
+}
+
+func TestMatchPodFailurePolicyNoRuleMatches(t *testing.T) {
+	policy := &batch.PodFailurePolicy{
+		Rules: []batch.PodFailurePolicyRule{
+			{
+				Action: batch.PodFailurePolicyActionFailJob,
+				OnExitCodes: &batch.PodFailurePolicyOnExitCodesRequirement{
+					Operator: batch.PodFailurePolicyOnExitCodesOpIn,
+					Values:   []int32{1},
+				},
+			},
+		},
+	}
+	pod := &v1.Pod{
+		Status: v1.PodStatus{
+			Phase:             v1.PodFailed,
+			ContainerStatuses: []v1.ContainerStatus{terminatedStatus("main", 2)},
+		},
+	}
+	action, matched := matchPodFailurePolicy(policy, pod)
+	if matched || action != batch.PodFailurePolicyActionCount {
+		t.Errorf("got (%v, %v), want (Count, false)", action, matched)
+	}
+}
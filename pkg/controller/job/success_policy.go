@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+)
+
+// matchSuccessPolicy checks whether the succeeded indexes recorded so far
+// satisfy any rule of the Job's SuccessPolicy. It returns true as soon as a
+// rule is met, in which case the Job controller should add the
+// SuccessCriteriaMet condition, terminate the remaining active Pods, and
+// transition the Job to Complete once they have stopped.
+//
+// completedIndexes uses the same compressed encoding as
+// JobStatus.CompletedIndexes. completions is .spec.completions; it is only
+// used to bound the indexes considered by SucceededCount when a rule does
+// not also specify SucceededIndexes.
+func matchSuccessPolicy(policy *batch.SuccessPolicy, completions int32, completedIndexes string) bool {
+	if policy == nil {
+		return false
+	}
+	succeeded := parseIndexSet(completedIndexes)
+	for _, rule := range policy.Rules {
+		if successPolicyRuleMatches(&rule, succeeded, completions) {
+			return true
+		}
+	}
+	return false
+}
+
+// successPolicyRuleMatches reports whether enough of the indexes a rule
+// cares about have succeeded. When both SucceededIndexes and SucceededCount
+// are set, this is a quorum: the rule is met once SucceededCount of the
+// indexes named by SucceededIndexes have succeeded, not only once every
+// named index has succeeded. This is what makes "leader index completes" and
+// quorum-style rules (e.g. SucceededIndexes:"0-9", SucceededCount:5) usable.
+func successPolicyRuleMatches(rule *batch.SuccessPolicyRule, succeeded sets.Set[int], completions int32) bool {
+	if rule.SucceededIndexes != nil {
+		required := parseIndexSet(*rule.SucceededIndexes)
+		met := succeeded.Intersection(required).Len()
+		if rule.SucceededCount == nil {
+			return met == required.Len()
+		}
+		return int32(met) >= *rule.SucceededCount
+	}
+	if rule.SucceededCount != nil {
+		return int32(succeeded.Len()) >= *rule.SucceededCount
+	}
+	return false
+}
+
+// parseIndexSet parses the compressed index encoding shared by
+// CompletedIndexes, FailedIndexes and SuccessPolicyRule.SucceededIndexes.
+// Unlike the stricter validation-time parser, it is best-effort: malformed
+// groups are skipped rather than erroring, since by the time the controller
+// reads these values they have already been validated or generated by the
+// controller itself.
+func parseIndexSet(indexesStr string) sets.Set[int] {
+	result := sets.New[int]()
+	if indexesStr == "" {
+		return result
+	}
+	for _, group := range strings.Split(indexesStr, ",") {
+		bounds := strings.SplitN(group, "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			continue
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.Atoi(bounds[1]); err != nil {
+				continue
+			}
+		}
+		for i := first; i <= last; i++ {
+			result.Insert(i)
+		}
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+)
+
+func TestMatchSuccessPolicyQuorumOverIndexSubset(t *testing.T) {
+	indexes := "0-9"
+	count := int32(5)
+	policy := &batch.SuccessPolicy{
+		Rules: []batch.SuccessPolicyRule{{SucceededIndexes: &indexes, SucceededCount: &count}},
+	}
+
+	// Only 5 of the 10 named indexes have succeeded: the quorum rule should
+	// already be satisfied, even though 5 of them (and all outside 0-9) have
+	// not.
+	if !matchSuccessPolicy(policy, 20, "0,1,2,3,4") {
+		t.Errorf("expected quorum rule (5 of 0-9) to be met by 5 succeeded indexes within range")
+	}
+}
+
+func TestMatchSuccessPolicyQuorumNotYetMet(t *testing.T) {
+	indexes := "0-9"
+	count := int32(5)
+	policy := &batch.SuccessPolicy{
+		Rules: []batch.SuccessPolicyRule{{SucceededIndexes: &indexes, SucceededCount: &count}},
+	}
+	if matchSuccessPolicy(policy, 20, "0,1,2,3") {
+		t.Errorf("expected quorum rule (5 of 0-9) not to be met by only 4 succeeded indexes")
+	}
+}
+
+func TestMatchSuccessPolicyQuorumIgnoresSuccessesOutsideIndexes(t *testing.T) {
+	indexes := "0-4"
+	count := int32(3)
+	policy := &batch.SuccessPolicy{
+		Rules: []batch.SuccessPolicyRule{{SucceededIndexes: &indexes, SucceededCount: &count}},
+	}
+	// 4 indexes have succeeded, but only 2 of them (0,1) are within 0-4.
+	if matchSuccessPolicy(policy, 20, "0,1,10,11") {
+		t.Errorf("expected successes outside succeededIndexes not to count towards the quorum")
+	}
+}
+
+func TestMatchSuccessPolicyIndexesOnlyRequiresAll(t *testing.T) {
+	indexes := "0-2"
+	policy := &batch.SuccessPolicy{
+		Rules: []batch.SuccessPolicyRule{{SucceededIndexes: &indexes}},
+	}
+	if matchSuccessPolicy(policy, 20, "0,1") {
+		t.Errorf("expected succeededIndexes without succeededCount to require every named index")
+	}
+	if !matchSuccessPolicy(policy, 20, "0,1,2") {
+		t.Errorf("expected succeededIndexes without succeededCount to match once every named index has succeeded")
+	}
+}
+
+func TestMatchSuccessPolicyCountOnly(t *testing.T) {
+	count := int32(3)
+	policy := &batch.SuccessPolicy{
+		Rules: []batch.SuccessPolicyRule{{SucceededCount: &count}},
+	}
+	if matchSuccessPolicy(policy, 20, "0,1") {
+		t.Errorf("expected succeededCount-only rule not to match with fewer successes than required")
+	}
+	if !matchSuccessPolicy(policy, 20, "5,6,7") {
+		t.Errorf("expected succeededCount-only rule to match once enough indexes (anywhere) have succeeded")
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internalversion
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "k8s.io/kubernetes/pkg/apis/batch"
+)
+
+// jobColumnDefinitions are the additional `kubectl get job -o wide` columns
+// for Indexed Jobs that use backoffLimitPerIndex.
+var jobColumnDefinitions = []metav1.TableColumnDefinition{
+	{Name: "Failed Indexes", Type: "string", Priority: 1, Description: "The failed completion indexes, using the same compressed encoding as the completed indexes."},
+}
+
+// printJob renders the wide-output Failed Indexes column for a Job, printing
+// "<none>" when the Job does not use per-index backoff tracking.
+func printJob(obj *batch.Job) []metav1.TableRow {
+	failedIndexes := "<none>"
+	if obj.Status.FailedIndexes != nil && *obj.Status.FailedIndexes != "" {
+		failedIndexes = *obj.Status.FailedIndexes
+	}
+	return []metav1.TableRow{
+		{Cells: []interface{}{failedIndexes}},
+	}
+}
@@ -29,11 +29,37 @@ type LocalTrafficDetector interface {
 	// IsImplemented returns true if the implementation does something, false otherwise
 	IsImplemented() bool
 
-	// IfLocal returns iptables arguments that will match traffic from a pod
-	IfLocal() []string
+	// JumpIfLocal returns one or more complete iptables rules, each of which
+	// jumps to target when traffic matches IfLocal. Most implementations
+	// return a single rule; composite detectors may return one rule per
+	// alternative, since iptables has no native OR within a single rule.
+	// Each returned []string is meant to be appended as its own rule.
+	JumpIfLocal(target string) [][]string
 
-	// IfNotLocal returns iptables arguments that will match traffic that is not from a pod
-	IfNotLocal() []string
+	// JumpIfNotLocal returns one or more complete iptables rules, each of
+	// which jumps to target when traffic matches IfNotLocal. See JumpIfLocal
+	// for why more than one rule may be returned.
+	JumpIfNotLocal(target string) [][]string
+}
+
+// jumpRule builds a "<matcher...> -j target" rule out of a matcher, or nil if
+// the matcher itself is empty (matches everything, nothing to jump on).
+func jumpRule(matcher []string, target string) []string {
+	if len(matcher) == 0 {
+		return nil
+	}
+	return append(append([]string{}, matcher...), "-j", target)
+}
+
+// singleJumpRule wraps jumpRule's result into the [][]string shape expected
+// by JumpIfLocal/JumpIfNotLocal, omitting the rule entirely when the matcher
+// is empty.
+func singleJumpRule(matcher []string, target string) [][]string {
+	rule := jumpRule(matcher, target)
+	if rule == nil {
+		return nil
+	}
+	return [][]string{rule}
 }
 
 type noOpLocalDetector struct{}
@@ -55,6 +81,14 @@ func (n *noOpLocalDetector) IfNotLocal() []string {
 	return nil // no-op; matches all traffic
 }
 
+func (n *noOpLocalDetector) JumpIfLocal(target string) [][]string {
+	return singleJumpRule(n.IfLocal(), target)
+}
+
+func (n *noOpLocalDetector) JumpIfNotLocal(target string) [][]string {
+	return singleJumpRule(n.IfNotLocal(), target)
+}
+
 type detectLocalByCIDR struct {
 	ifLocal    []string
 	ifNotLocal []string
@@ -87,3 +121,175 @@ func (d *detectLocalByCIDR) IfLocal() []string {
 func (d *detectLocalByCIDR) IfNotLocal() []string {
 	return d.ifNotLocal
 }
+
+func (d *detectLocalByCIDR) JumpIfLocal(target string) [][]string {
+	return singleJumpRule(d.IfLocal(), target)
+}
+
+func (d *detectLocalByCIDR) JumpIfNotLocal(target string) [][]string {
+	return singleJumpRule(d.IfNotLocal(), target)
+}
+
+// NewDetectLocalByCIDRs implements the LocalTrafficDetector interface using a list of CIDRs. This can be used when
+// a cluster's pod CIDRs are not contiguous, e.g. in dual-stack or multi-CIDR cluster topologies, and traffic should
+// be considered local if its source matches any of them.
+func NewDetectLocalByCIDRs(cidrs []string, ipt utiliptables.Interface) (LocalTrafficDetector, error) {
+	if len(cidrs) == 0 {
+		return nil, fmt.Errorf("no CIDRs provided")
+	}
+	detectors := make([]LocalTrafficDetector, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		d, err := NewDetectLocalByCIDR(cidr, ipt)
+		if err != nil {
+			return nil, err
+		}
+		detectors = append(detectors, d)
+	}
+	return NewDetectLocalByAny(detectors), nil
+}
+
+type detectLocalByBridgeInterface struct {
+	ifLocal    []string
+	ifNotLocal []string
+}
+
+// NewDetectLocalByBridgeInterface implements the LocalTrafficDetector interface using a bridge interface name. This
+// can be used for CNI plugins that place all pod traffic onto a single, known bridge (e.g. cbr0).
+func NewDetectLocalByBridgeInterface(ifaceName string) (LocalTrafficDetector, error) {
+	if ifaceName == "" {
+		return nil, fmt.Errorf("no bridge interface name provided")
+	}
+	return &detectLocalByBridgeInterface{
+		ifLocal:    []string{"-i", ifaceName},
+		ifNotLocal: []string{"!", "-i", ifaceName},
+	}, nil
+}
+
+func (d *detectLocalByBridgeInterface) IsImplemented() bool {
+	return true
+}
+
+func (d *detectLocalByBridgeInterface) IfLocal() []string {
+	return d.ifLocal
+}
+
+func (d *detectLocalByBridgeInterface) IfNotLocal() []string {
+	return d.ifNotLocal
+}
+
+func (d *detectLocalByBridgeInterface) JumpIfLocal(target string) [][]string {
+	return singleJumpRule(d.IfLocal(), target)
+}
+
+func (d *detectLocalByBridgeInterface) JumpIfNotLocal(target string) [][]string {
+	return singleJumpRule(d.IfNotLocal(), target)
+}
+
+type detectLocalByInterfaceNamePrefix struct {
+	ifLocal    []string
+	ifNotLocal []string
+}
+
+// NewDetectLocalByInterfaceNamePrefix implements the LocalTrafficDetector interface using an interface name prefix.
+// This can be used for CNI plugins that place pod traffic behind a per-pod veth whose name always starts with a
+// known prefix (e.g. "cali", "veth").
+func NewDetectLocalByInterfaceNamePrefix(prefix string) (LocalTrafficDetector, error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("no interface name prefix provided")
+	}
+	return &detectLocalByInterfaceNamePrefix{
+		ifLocal:    []string{"-i", prefix + "+"},
+		ifNotLocal: []string{"!", "-i", prefix + "+"},
+	}, nil
+}
+
+func (d *detectLocalByInterfaceNamePrefix) IsImplemented() bool {
+	return true
+}
+
+func (d *detectLocalByInterfaceNamePrefix) IfLocal() []string {
+	return d.ifLocal
+}
+
+func (d *detectLocalByInterfaceNamePrefix) IfNotLocal() []string {
+	return d.ifNotLocal
+}
+
+func (d *detectLocalByInterfaceNamePrefix) JumpIfLocal(target string) [][]string {
+	return singleJumpRule(d.IfLocal(), target)
+}
+
+func (d *detectLocalByInterfaceNamePrefix) JumpIfNotLocal(target string) [][]string {
+	return singleJumpRule(d.IfNotLocal(), target)
+}
+
+// detectLocalByAny is a composite LocalTrafficDetector that ORs the IfLocal
+// matches of several detectors together, and correspondingly ANDs their
+// IfNotLocal negations, so that traffic is considered local if any of the
+// underlying detectors considers it local (e.g. "local = source in podCIDR
+// OR ingress on cbr0"). iptables rules are an implicit AND of their match
+// extensions, so the OR is expressed as one rule per detector, and the AND
+// of negations is expressed as a single rule combining every detector's
+// IfNotLocal matcher.
+type detectLocalByAny struct {
+	detectors []LocalTrafficDetector
+}
+
+// NewDetectLocalByAny returns a LocalTrafficDetector that combines several
+// detectors: traffic is local if it matches any of them. Detectors that are
+// not implemented (IsImplemented() == false) are ignored. If at most one
+// detector remains after filtering, it is returned directly instead of
+// wrapping it.
+func NewDetectLocalByAny(detectors []LocalTrafficDetector) LocalTrafficDetector {
+	implemented := make([]LocalTrafficDetector, 0, len(detectors))
+	for _, d := range detectors {
+		if d != nil && d.IsImplemented() {
+			implemented = append(implemented, d)
+		}
+	}
+	if len(implemented) == 0 {
+		return NewNoOpLocalDetector()
+	}
+	if len(implemented) == 1 {
+		return implemented[0]
+	}
+	return &detectLocalByAny{detectors: implemented}
+}
+
+func (d *detectLocalByAny) IsImplemented() bool {
+	return true
+}
+
+// JumpIfLocal returns one jump rule per underlying detector, so that traffic
+// matching ANY of them jumps to target (logical OR across separate rules).
+func (d *detectLocalByAny) JumpIfLocal(target string) [][]string {
+	var rules [][]string
+	for _, det := range d.detectors {
+		rules = append(rules, det.JumpIfLocal(target)...)
+	}
+	return rules
+}
+
+// JumpIfNotLocal implements "traffic is not local" as the AND of every
+// underlying detector's negation. iptables rejects a single rule combining
+// more than one matcher of the same kind (e.g. two "-s" flags), which rules
+// out simply concatenating each detector's IfNotLocal() tokens whenever more
+// than one CIDR-based detector is combined (as NewDetectLocalByCIDRs does).
+// Instead this follows the standard kube-proxy RETURN idiom: one rule per
+// detector that RETURNs as soon as traffic is recognized as local by that
+// detector, followed by an unconditional fall-through rule that jumps to
+// target. Only traffic that matched none of the RETURN rules reaches the
+// final rule, i.e. traffic that is local according to no detector.
+//
+// The returned rules must be installed, in order, in a chain dedicated to
+// this check (not appended ad hoc into a chain shared with unrelated
+// rules), since correctness depends on the RETURN rules being evaluated
+// before the fall-through jump.
+func (d *detectLocalByAny) JumpIfNotLocal(target string) [][]string {
+	rules := make([][]string, 0, len(d.detectors)+1)
+	for _, det := range d.detectors {
+		rules = append(rules, det.JumpIfLocal("RETURN")...)
+	}
+	rules = append(rules, []string{"-j", target})
+	return rules
+}
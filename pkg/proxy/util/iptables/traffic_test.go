@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iptables
+
+import (
+	"reflect"
+	"testing"
+
+	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
+	iptablestest "k8s.io/kubernetes/pkg/util/iptables/testing"
+)
+
+func newFakeIPTables(isIPv6 bool) utiliptables.Interface {
+	protocol := utiliptables.ProtocolIPv4
+	if isIPv6 {
+		protocol = utiliptables.ProtocolIPv6
+	}
+	return iptablestest.NewFake().SetProtocol(protocol)
+}
+
+func TestDetectLocalByAnyOfOneIsReturnedDirectly(t *testing.T) {
+	ipt := newFakeIPTables(false)
+	cidrDetector, err := NewDetectLocalByCIDR("10.0.0.0/8", ipt)
+	if err != nil {
+		t.Fatalf("NewDetectLocalByCIDR: %v", err)
+	}
+	combined := NewDetectLocalByAny([]LocalTrafficDetector{cidrDetector})
+	if combined != cidrDetector {
+		t.Errorf("expected NewDetectLocalByAny with a single detector to return it directly")
+	}
+}
+
+func TestDetectLocalByAnyOfNoneIsNoOp(t *testing.T) {
+	combined := NewDetectLocalByAny(nil)
+	if combined.IsImplemented() {
+		t.Errorf("expected NewDetectLocalByAny with no detectors to be a no-op")
+	}
+}
+
+// TestDetectLocalByAnyJumpIfLocalDoesNotPanic ensures that calling the public
+// LocalTrafficDetector interface methods on a composite detector never
+// panics, since LocalTrafficDetector is the interface every call site uses
+// and NewDetectLocalByCIDRs routinely returns a composite.
+func TestDetectLocalByAnyJumpIfLocalDoesNotPanic(t *testing.T) {
+	ipt := newFakeIPTables(false)
+	combined, err := NewDetectLocalByCIDRs([]string{"10.0.0.0/8", "10.1.0.0/16"}, ipt)
+	if err != nil {
+		t.Fatalf("NewDetectLocalByCIDRs: %v", err)
+	}
+
+	var detector LocalTrafficDetector = combined
+	if !detector.IsImplemented() {
+		t.Fatalf("expected the composite detector to be implemented")
+	}
+
+	jumpLocal := detector.JumpIfLocal("LOCAL")
+	want := [][]string{
+		{"-s", "10.0.0.0/8", "-j", "LOCAL"},
+		{"-s", "10.1.0.0/16", "-j", "LOCAL"},
+	}
+	if !reflect.DeepEqual(jumpLocal, want) {
+		t.Errorf("JumpIfLocal: got %v, want %v", jumpLocal, want)
+	}
+
+	jumpNotLocal := detector.JumpIfNotLocal("NOT-LOCAL")
+	wantNotLocal := [][]string{
+		{"-s", "10.0.0.0/8", "-j", "RETURN"},
+		{"-s", "10.1.0.0/16", "-j", "RETURN"},
+		{"-j", "NOT-LOCAL"},
+	}
+	if !reflect.DeepEqual(jumpNotLocal, wantNotLocal) {
+		t.Errorf("JumpIfNotLocal: got %v, want %v", jumpNotLocal, wantNotLocal)
+	}
+}
+
+func TestDetectLocalByCIDRJumpRules(t *testing.T) {
+	ipt := newFakeIPTables(false)
+	d, err := NewDetectLocalByCIDR("10.0.0.0/8", ipt)
+	if err != nil {
+		t.Fatalf("NewDetectLocalByCIDR: %v", err)
+	}
+	want := [][]string{{"-s", "10.0.0.0/8", "-j", "LOCAL"}}
+	if got := d.JumpIfLocal("LOCAL"); !reflect.DeepEqual(got, want) {
+		t.Errorf("JumpIfLocal: got %v, want %v", got, want)
+	}
+	wantNot := [][]string{{"!", "-s", "10.0.0.0/8", "-j", "NOT-LOCAL"}}
+	if got := d.JumpIfNotLocal("NOT-LOCAL"); !reflect.DeepEqual(got, wantNot) {
+		t.Errorf("JumpIfNotLocal: got %v, want %v", got, wantNot)
+	}
+}
+
+func TestNoOpLocalDetectorJumpRulesAreEmpty(t *testing.T) {
+	d := NewNoOpLocalDetector()
+	if d.IsImplemented() {
+		t.Errorf("expected the no-op detector to report unimplemented")
+	}
+	if got := d.JumpIfLocal("LOCAL"); got != nil {
+		t.Errorf("expected no JumpIfLocal rules from the no-op detector, got %v", got)
+	}
+	if got := d.JumpIfNotLocal("NOT-LOCAL"); got != nil {
+		t.Errorf("expected no JumpIfNotLocal rules from the no-op detector, got %v", got)
+	}
+}